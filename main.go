@@ -2,11 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 
 	"github.com/brendanjryan/ccheck/pkg"
-	"github.com/fatih/color"
+	"github.com/brendanjryan/ccheck/pkg/reporter"
 	"github.com/urfave/cli"
 )
 
@@ -21,11 +22,20 @@ func main() {
 	as := args{}
 
 	app.Flags = []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "p",
+			Value: &cli.StringSlice{"policies"},
+			Usage: "policy source to load from: a local directory, or an https://, oci:// or git+https://...#ref URI (repeatable)",
+		},
+		cli.BoolFlag{
+			Name:        "refresh",
+			Usage:       "force remote policy sources to be re-fetched rather than served from cache",
+			Destination: &as.refresh,
+		},
 		cli.StringFlag{
-			Name:        "p",
-			Value:       "policies",
-			Usage:       "directory which policy definitions live in",
-			Destination: &as.policyDir,
+			Name:        "pubkey",
+			Usage:       "public key (cosign or minisign) to verify remote policy bundles against",
+			Destination: &as.pubkey,
 		},
 		cli.StringFlag{
 			Name:        "n",
@@ -38,10 +48,42 @@ func main() {
 			Usage:       "whether or not strict mode is enabled",
 			Destination: &as.strict,
 		},
+		cli.BoolFlag{
+			Name:        "no-embedded",
+			Usage:       "disable the bundled, built-in policy set",
+			Destination: &as.noEmbedded,
+		},
+		cli.StringSliceFlag{
+			Name:  "embedded-namespaces",
+			Usage: "restrict the bundled policy set to these namespaces (default: all)",
+		},
+		cli.StringFlag{
+			Name:        "f, format",
+			Value:       "text",
+			Usage:       "output format: text, json, sarif, junit, github",
+			Destination: &as.format,
+		},
+		cli.StringSliceFlag{
+			Name:  "d, data",
+			Usage: "YAML/JSON file to load as data.* documents alongside the input (repeatable)",
+		},
+		cli.StringFlag{
+			Name:        "V, values",
+			Usage:       "file mapping policy namespaces to value trees, merged into data.*",
+			Destination: &as.valuesFile,
+		},
+		cli.StringFlag{
+			Name:        "input-key",
+			Usage:       "mount the parsed config under input.<key> instead of the root of input",
+			Destination: &as.inputKey,
+		},
 	}
 
 	app.Action = func(c *cli.Context) error {
 		as.configs = c.Args()
+		as.policySources = c.StringSlice("p")
+		as.embeddedNamespaces = c.StringSlice("embedded-namespaces")
+		as.dataFiles = c.StringSlice("d")
 		err := confCheck(as)
 		if err != nil {
 			log.Fatal(cli.NewExitError("error: "+err.Error(), 1))
@@ -50,6 +92,11 @@ func main() {
 		return nil
 	}
 
+	app.Commands = []cli.Command{
+		serveCommand(),
+		testCommand(),
+	}
+
 	err := app.Run(os.Args)
 	if err != nil {
 		log.Fatal("error creating CLI application: ", err)
@@ -61,7 +108,15 @@ func main() {
 func confCheck(as args) error {
 	ctx := context.Background()
 
-	cc := pkg.NewConfChecker(as.namespace, as.policyDir, as.configs)
+	cc := pkg.NewConfChecker(as.namespace, as.policySources, as.configs,
+		pkg.WithNoEmbedded(as.noEmbedded),
+		pkg.WithEmbeddedNamespaces(as.embeddedNamespaces),
+		pkg.WithRefresh(as.refresh),
+		pkg.WithPubKey(as.pubkey),
+		pkg.WithData(as.dataFiles),
+		pkg.WithValues(as.valuesFile),
+		pkg.WithInputKey(as.inputKey),
+	)
 
 	cr, err := cc.Run(ctx)
 	if err != nil {
@@ -69,35 +124,48 @@ func confCheck(as args) error {
 		return err
 	}
 
-	p := printer{}
+	r, err := reporter.New(as.format, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	hasFailures := false
 	for f, res := range cr {
 		if len(res.Warnings) == 0 && len(res.Failures) == 0 {
-			p.ok(f)
+			r.Ok(f)
 		}
 
 		for _, w := range res.Warnings {
 			if as.strict {
-				p.err(f, w)
+				hasFailures = true
+				r.Err(f, w)
 				continue
 			}
 
-			p.warning(f, w)
+			r.Warning(f, w)
 		}
 
 		for _, fa := range res.Failures {
-			// trap an error just so we exit with the right code
-			err = fa
-			p.err(f, fa)
+			hasFailures = true
+			r.Err(f, fa)
 		}
 	}
 
+	if err := r.Flush(); err != nil {
+		return err
+	}
+
+	if hasFailures {
+		return errors.New("one or more policy checks failed")
+	}
+
 	return nil
 }
 
 // args represents all command line arguments supported by this script
 type args struct {
-	// the directory which policy files live in
-	policyDir string
+	// the policy sources (local directories or remote URIs) to load from
+	policySources []string
 
 	// the namespace rules live in:
 	// https://www.openpolicyagent.org/docs/latest/how-do-i-write-policies#packages
@@ -106,21 +174,30 @@ type args struct {
 	// whether or not strict mode is enabled
 	strict bool
 
-	// a list of config files we will check
-	configs []string
-}
+	// whether or not the bundled, built-in policy set is disabled
+	noEmbedded bool
 
-// printer controlls printing the results of this script in a formatted manner.
-type printer struct{}
+	// forces remote policy sources to be re-fetched rather than cached
+	refresh bool
 
-func (p printer) err(file string, err error) {
-	color.Red("Failure: %s - %s", file, err)
-}
+	// public key used to verify remote policy bundles, if set
+	pubkey string
 
-func (p printer) warning(file string, err error) {
-	color.Yellow("Warning: %s - %s", file, err)
-}
+	// restricts the embedded policy set to these namespaces; empty means all
+	embeddedNamespaces []string
+
+	// the output format results are rendered in: text, json, sarif, junit, github
+	format string
+
+	// YAML/JSON files loaded as data.* documents alongside the input
+	dataFiles []string
 
-func (p printer) ok(file string) {
-	color.Green("Passed: %s", file)
+	// file mapping policy namespaces to value trees, merged into data.*
+	valuesFile string
+
+	// mounts the parsed config under input.<inputKey> instead of the root
+	inputKey string
+
+	// a list of config files we will check
+	configs []string
 }
@@ -1,10 +1,12 @@
 package pkg
 
 import (
-	"bytes"
 	"context"
+	"embed"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -12,11 +14,25 @@ import (
 	"strings"
 
 	"github.com/brendanjryan/ccheck/pkg/parsers"
+	"github.com/brendanjryan/ccheck/pkg/sources"
 	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
-	"github.com/uber-go/multierr"
+	"github.com/open-policy-agent/opa/storage"
+	"github.com/open-policy-agent/opa/storage/inmem"
 )
 
+// embeddedPolicies holds the default, curated set of policies that ship with
+// ccheck so that first-run users get immediate value instead of a "policies
+// directory not found" error. Policies placed under policyDir take
+// precedence over these when both define a module with the same name.
+//
+//go:embed embedded/*.rego
+var embeddedPolicies embed.FS
+
+// embeddedPoliciesDir is the directory within embeddedPolicies that the
+// bundled *.rego files live in.
+const embeddedPoliciesDir = "embedded"
+
 var (
 	failQ = regexp.MustCompile("deny_?[a-zA-Z]*")
 	warnQ = regexp.MustCompile("warn_?[a-zA-Z]*")
@@ -27,33 +43,156 @@ type ConfChecker struct {
 	// the namespace that the rules live in:
 	// https://www.openpolicyagent.org/docs/latest/how-do-i-write-policies#packages
 	namespace string
-	policyDir string
-	configs   []string
+
+	// policySources is a list of policy bundle locations: local directories,
+	// or https://, oci:// and git+https:// URIs resolved via pkg/sources.
+	policySources []string
+	configs       []string
+
+	// dataFiles are YAML/JSON files loaded as data.* documents alongside
+	// the input, e.g. organizational context like allowed registries.
+	dataFiles []string
+
+	// valuesFile maps policy namespaces to value trees, merged into data.*
+	// alongside dataFiles.
+	valuesFile string
+
+	// inputKey, if set, mounts the parsed config under input.<inputKey>
+	// instead of the root of input.
+	inputKey string
+
+	compilerOpts []CompilerOption
+}
+
+// ConfCheckerOption customizes the behavior of a ConfChecker returned by
+// NewConfChecker.
+type ConfCheckerOption func(*ConfChecker)
+
+// WithNoEmbedded disables the bundled, built-in policy set, requiring all
+// policies to come from policyDir. This is useful for air-gapped or strict
+// environments that want to vet exactly what they ship.
+func WithNoEmbedded(noEmbedded bool) ConfCheckerOption {
+	return func(c *ConfChecker) {
+		c.compilerOpts = append(c.compilerOpts, withNoEmbedded(noEmbedded))
+	}
+}
+
+// WithEmbeddedNamespaces restricts the bundled policy set to only those
+// modules whose package falls under one of the given namespaces. An empty
+// list loads every embedded package.
+func WithEmbeddedNamespaces(namespaces []string) ConfCheckerOption {
+	return func(c *ConfChecker) {
+		c.compilerOpts = append(c.compilerOpts, withEmbeddedNamespaces(namespaces))
+	}
+}
+
+// WithRefresh forces every remote policy source to be re-fetched rather than
+// served from cache.
+func WithRefresh(refresh bool) ConfCheckerOption {
+	return func(c *ConfChecker) {
+		c.compilerOpts = append(c.compilerOpts, withRefresh(refresh))
+	}
 }
 
-func NewConfChecker(namespace string, policyDir string, configs []string) *ConfChecker {
-	return &ConfChecker{
-		namespace: namespace,
-		policyDir: policyDir,
-		configs:   configs,
+// WithPubKey verifies every fetched remote bundle's signature against
+// pubkey (a cosign or minisign public key) before trusting it.
+func WithPubKey(pubkey string) ConfCheckerOption {
+	return func(c *ConfChecker) {
+		c.compilerOpts = append(c.compilerOpts, withPubKey(pubkey))
 	}
 }
 
+// WithData loads the given YAML/JSON files as data.* documents, available
+// to every policy alongside the input. Later files win on conflicting
+// top-level keys.
+func WithData(dataFiles []string) ConfCheckerOption {
+	return func(c *ConfChecker) {
+		c.dataFiles = dataFiles
+	}
+}
+
+// WithValues loads a file mapping dotted policy namespaces (e.g.
+// "main.allowed_registries") to value trees, merged into data.* alongside
+// any files loaded via WithData. An empty path is a no-op.
+func WithValues(valuesFile string) ConfCheckerOption {
+	return func(c *ConfChecker) {
+		c.valuesFile = valuesFile
+	}
+}
+
+// WithInputKey mounts each parsed config under input.<key> instead of the
+// root of input, matching how Conftest-style tools structure inputs.
+func WithInputKey(key string) ConfCheckerOption {
+	return func(c *ConfChecker) {
+		c.inputKey = key
+	}
+}
+
+// NewConfChecker builds a ConfChecker that checks configs against the
+// policies resolved from policySources -- each either a local directory or
+// an https://, oci:// or git+https://...#ref URI.
+func NewConfChecker(namespace string, policySources []string, configs []string, opts ...ConfCheckerOption) *ConfChecker {
+	c := &ConfChecker{
+		namespace:     namespace,
+		policySources: policySources,
+		configs:       configs,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
 // CheckResults is a map of fileName -> results of a check operation
 type CheckResults map[string]CheckResult
 
 // CheckResult represents the results of a check operation for a single file.
 type CheckResult struct {
-	Failures []error
-	Warnings []error
+	Failures []Finding
+	Warnings []Finding
+}
+
+// Finding represents a single policy violation or warning, enriched with
+// whatever metadata the rule's author declared via OPA annotations
+// (https://www.openpolicyagent.org/docs/latest/annotations/).
+type Finding struct {
+	// RuleID identifies the rule that produced this finding. It is taken
+	// from the rule's "custom.id" annotation, falling back to the rule's
+	// name (e.g. "deny_privileged") when no id is declared.
+	RuleID string `json:"ruleId"`
+
+	// Severity is sourced from the rule's "custom.severity" annotation, e.g.
+	// "high" or "low". It is empty when undeclared.
+	Severity string `json:"severity"`
+
+	// Title is the rule's declared title, if any.
+	Title string `json:"title"`
+
+	// Message is the string produced by evaluating the rule against the
+	// input, e.g. `msg := sprintf("container %q must not run as privileged", [c.name])`.
+	Message string `json:"message"`
+
+	// Location is the file:line the rule is defined at.
+	Location string `json:"location"`
+}
+
+// String renders a Finding the way the CLI printer expects to format it.
+func (f Finding) String() string {
+	if f.RuleID == "" {
+		return f.Message
+	}
+
+	return fmt.Sprintf("[%s] %s", f.RuleID, f.Message)
 }
 
 // Run bootstraps the ConfChecker and performs checks against all of the
 // requested files.
 func (c *ConfChecker) Run(ctx context.Context) (CheckResults, error) {
 	// load rules from rule directory and construct an AST
-	compiler := NewCompiler(c.policyDir)
-	err := compiler.Build()
+	compiler := NewCompiler(c.policySources, c.compilerOpts...)
+	err := compiler.Build(ctx)
 	if err != nil {
 		return CheckResults{}, fmt.Errorf("error loading rules: %s", err)
 	}
@@ -64,10 +203,15 @@ func (c *ConfChecker) Run(ctx context.Context) (CheckResults, error) {
 		return CheckResults{}, fmt.Errorf("error loading configs: %s", err)
 	}
 
+	store, err := c.loadStore()
+	if err != nil {
+		return CheckResults{}, fmt.Errorf("error loading data: %s", err)
+	}
+
 	// run files against rules defined by AST
 	res := CheckResults{}
 	for name, parts := range cfs {
-		fs, ws, err := c.processFile(ctx, c.namespace, name, parts, compiler.Compiler)
+		fs, ws, err := c.processFile(ctx, c.namespace, name, parts, compiler.Compiler, store)
 		if err != nil {
 			return CheckResults{}, fmt.Errorf("error processiong file: %s", err)
 		}
@@ -81,7 +225,10 @@ func (c *ConfChecker) Run(ctx context.Context) (CheckResults, error) {
 	return res, nil
 }
 
-// loadsConfigs retrieves the config files and splits them into distinct chunks
+// loadsConfigs retrieves the config files and splits them into distinct
+// chunks, delegating the splitting itself to each file's Parser since it's
+// format-specific (YAML's "---" separator has no equivalent in, say, HCL or
+// a Dockerfile).
 func (c *ConfChecker) loadConfigs(ctx context.Context, files []string) (map[string][][]byte, error) {
 
 	res := map[string][][]byte{}
@@ -97,23 +244,193 @@ func (c *ConfChecker) loadConfigs(ctx context.Context, files []string) (map[stri
 			return nil, fmt.Errorf("unable to open file %s: %s", f, err)
 		}
 
-		// split on k8s linebreaks if any exist
-		parts := bytes.Split(data, []byte("\n---\n"))
+		p, err := parsers.Get(filePath)
+		if err != nil {
+			return nil, err
+		}
 
-		res[filePath] = parts
+		res[filePath] = p.Split(data)
 	}
 
 	return res, nil
 }
 
-func (c *ConfChecker) processFile(ctx context.Context, namespace string, fileName string, parts [][]byte, compiler *ast.Compiler) ([]error, []error, error) {
+// loadStore builds the storage.Store backing data.* for every query, from
+// c.dataFiles and c.valuesFile. It returns a nil store when neither is
+// configured, so evaluation behaves exactly as before this feature existed.
+func (c *ConfChecker) loadStore() (storage.Store, error) {
+	if len(c.dataFiles) == 0 && c.valuesFile == "" {
+		return nil, nil
+	}
+
+	data, err := loadDataFiles(c.dataFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := loadValuesFile(c.valuesFile)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range values {
+		data[k] = v
+	}
+
+	return inmem.NewFromObject(data), nil
+}
+
+// loadDataFiles parses every file in paths as a top-level object and
+// shallow-merges them into a single data.* document, later files winning on
+// conflicting keys.
+func loadDataFiles(paths []string) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+
+	for _, p := range paths {
+		doc, err := parseDataFile(p)
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range doc {
+			data[k] = v
+		}
+	}
+
+	return data, nil
+}
+
+// loadValuesFile parses a file mapping dotted policy namespaces (e.g.
+// "main.allowed_registries") to value trees, and nests each under the
+// corresponding path in data.*.
+func loadValuesFile(valuesFile string) (map[string]interface{}, error) {
+	if valuesFile == "" {
+		return map[string]interface{}{}, nil
+	}
+
+	doc, err := parseDataFile(valuesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{}
+	for ns, v := range doc {
+		setNested(data, strings.Split(ns, "."), v)
+	}
+
+	return data, nil
+}
+
+func parseDataFile(path string) (map[string]interface{}, error) {
+	p, err := parsers.Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open data file %s: %s", path, err)
+	}
+
+	var doc map[string]interface{}
+	if err := p.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("error parsing data file %s: %s", path, err)
+	}
+
+	return doc, nil
+}
+
+// setNested sets v at path within m, creating intermediate maps as needed.
+func setNested(m map[string]interface{}, path []string, v interface{}) {
+	if len(path) == 1 {
+		m[path[0]] = v
+		return
+	}
+
+	next, ok := m[path[0]].(map[string]interface{})
+	if !ok {
+		next = map[string]interface{}{}
+		m[path[0]] = next
+	}
+
+	setNested(next, path[1:], v)
+}
+
+// ruleMeta holds the metadata a rule declared via OPA annotations, used to
+// enrich every Finding a query against that rule produces.
+type ruleMeta struct {
+	ruleID   string
+	severity string
+	title    string
+	location string
+}
+
+// ruleMetaFor derives a ruleMeta for rule r, falling back to its bare name
+// when no annotations are present.
+func ruleMetaFor(as *ast.AnnotationSet, r *ast.Rule, name string) ruleMeta {
+	m := ruleMeta{ruleID: name}
+	if r.Location != nil {
+		m.location = r.Location.String()
+	}
+
+	for _, a := range as.GetRuleScope(r) {
+		if a.Title != "" {
+			m.title = a.Title
+		}
+		if id, ok := a.Custom["id"].(string); ok && id != "" {
+			m.ruleID = id
+		}
+		if sev, ok := a.Custom["severity"].(string); ok && sev != "" {
+			m.severity = sev
+		}
+	}
+
+	return m
+}
+
+func (c *ConfChecker) processFile(ctx context.Context, namespace string, fileName string, parts [][]byte, compiler *ast.Compiler, store storage.Store) ([]Finding, []Finding, error) {
 	p, err := parsers.Get(fileName)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// run checker over each "configuration part" of each file
+	var fails []Finding
+	var warns []Finding
+	for _, part := range parts {
+		var input interface{}
+		err = p.Unmarshal(part, &input)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if c.inputKey != "" {
+			input = map[string]interface{}{c.inputKey: input}
+		}
+
+		res, err := EvaluateInput(ctx, namespace, input, compiler, store)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fails = append(fails, res.Failures...)
+		warns = append(warns, res.Warnings...)
+	}
+
+	return fails, warns, nil
+}
+
+// EvaluateInput runs every deny_*/warn_* rule compiled into compiler, under
+// namespace, against a single already-parsed input document. store backs
+// data.* for the query and may be nil. It is the unit of work
+// ConfChecker.Run performs per config "part", exported so that other entry
+// points (e.g. pkg/server) can reuse a pre-built compiler without going
+// through file loading.
+func EvaluateInput(ctx context.Context, namespace string, input interface{}, compiler *ast.Compiler, store storage.Store) (CheckResult, error) {
+	as := compiler.GetAnnotationSet()
+
 	var fQueries []string
 	var wQueries []string
+	meta := map[string]ruleMeta{}
 	for _, m := range compiler.Modules {
 		for _, r := range m.Rules {
 			n := r.Head.Name.String()
@@ -123,77 +440,85 @@ func (c *ConfChecker) processFile(ctx context.Context, namespace string, fileNam
 			if failQ.MatchString(n) {
 				fQueries = append(fQueries, n)
 			}
-
+			if _, ok := meta[n]; !ok {
+				meta[n] = ruleMetaFor(as, r, n)
+			}
 		}
 	}
 
-	// run checker over each "configuration part" of each file
-	var fails []error
-	var warns []error
-	for _, part := range parts {
-		var input interface{}
-		err = p([]byte(part), &input)
+	var fails []Finding
+	var warns []Finding
+	for _, fq := range fQueries {
+		fs, err := runQuery(ctx, fmt.Sprintf("data.%s.%s", namespace, fq), input, compiler, store, meta[fq])
 		if err != nil {
-			return nil, nil, err
-		}
-
-		for _, fq := range fQueries {
-			fs := runQuery(ctx, fmt.Sprintf("data.%s.%s", namespace, fq), input, compiler)
-			fails = append(fails, fs)
+			return CheckResult{}, err
 		}
+		fails = append(fails, fs...)
+	}
 
-		for _, wq := range wQueries {
-			ws := runQuery(ctx, fmt.Sprintf("data.%s.%s", namespace, wq), input, compiler)
-			warns = append(warns, ws)
+	for _, wq := range wQueries {
+		ws, err := runQuery(ctx, fmt.Sprintf("data.%s.%s", namespace, wq), input, compiler, store, meta[wq])
+		if err != nil {
+			return CheckResult{}, err
 		}
+		warns = append(warns, ws...)
 	}
 
-	return fails, warns, nil
+	return CheckResult{Failures: fails, Warnings: warns}, nil
 }
 
-func runQuery(ctx context.Context, query string, input interface{}, compiler *ast.Compiler) error {
-	hasResults := func(expression interface{}) bool {
-		if v, ok := expression.([]interface{}); ok {
-			return len(v) > 0
-		}
-		return false
-	}
-
-	rq, err := Query(query).Build(compiler, input)
+func runQuery(ctx context.Context, query string, input interface{}, compiler *ast.Compiler, store storage.Store, m ruleMeta) ([]Finding, error) {
+	rq, err := Query(query).Build(compiler, input, store)
 	if err != nil {
-		return errors.New("error constructing query : " + err.Error())
+		return nil, errors.New("error constructing query : " + err.Error())
 	}
 
 	pq, err := rq.PrepareForEval(ctx)
 	if err != nil {
-		return errors.New("error preparing for evaluation: " + err.Error())
+		return nil, errors.New("error preparing for evaluation: " + err.Error())
 	}
 
 	rr, err := pq.Eval(ctx)
 	if err != nil {
-		return errors.New("error evaluating rules: " + err.Error())
+		return nil, errors.New("error evaluating rules: " + err.Error())
 	}
 
-	// extract errors from "values" of evaluation
+	// build a Finding from each "value" the query produced
+	var findings []Finding
 	for _, r := range rr {
 		for _, e := range r.Expressions {
-			value := e.Value
-			if hasResults(value) {
-				for _, v := range value.([]interface{}) {
-					err = multierr.Append(err, errors.New(v.(string)))
+			v, ok := e.Value.([]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, item := range v {
+				msg, ok := item.(string)
+				if !ok {
+					continue
 				}
+
+				findings = append(findings, Finding{
+					RuleID:   m.ruleID,
+					Severity: m.severity,
+					Title:    m.title,
+					Message:  msg,
+					Location: m.location,
+				})
 			}
 		}
 	}
 
-	return err
+	return findings, nil
 }
 
 // Query represents a rego query.
 type Query string
 
-// Build constructs reqo query -- to run call .Eval(context.Context) on the resultant *rego.Rego struct
-func (q Query) Build(compiler *ast.Compiler, in interface{}) (*rego.Rego, error) {
+// Build constructs reqo query -- to run call .Eval(context.Context) on the resultant *rego.Rego struct.
+// store backs data.* for the query and may be nil, in which case the query
+// sees no data beyond what the compiled policies define.
+func (q Query) Build(compiler *ast.Compiler, in interface{}, store storage.Store) (*rego.Rego, error) {
 
 	opts := []func(*rego.Rego){
 		rego.Query(string(q)),
@@ -201,76 +526,358 @@ func (q Query) Build(compiler *ast.Compiler, in interface{}) (*rego.Rego, error)
 		rego.Input(in),
 	}
 
+	if store != nil {
+		opts = append(opts, rego.Store(store))
+	}
+
 	return rego.New(opts...), nil
 }
 
-// Compiler is a compiled set of policies defined by *.rego files in the
-// specified policy dir.
+// Compiler is a compiled set of policies resolved from one or more policy
+// sources (local directories, or https://, oci:// and git+https:// URIs),
+// overlaid on top of the bundle of policies embedded into the ccheck
+// binary.
 type Compiler struct {
 	*ast.Compiler
-	policyDir string
+	policySources []string
+
+	noEmbedded         bool
+	embeddedNamespaces []string
+	refresh            bool
+	pubkey             string
+}
+
+// CompilerOption customizes the behavior of a Compiler returned by
+// NewCompiler.
+type CompilerOption func(*Compiler)
+
+// withNoEmbedded disables loading of the embedded policy bundle.
+func withNoEmbedded(noEmbedded bool) CompilerOption {
+	return func(c *Compiler) {
+		c.noEmbedded = noEmbedded
+	}
+}
+
+// WithNoEmbeddedBundle disables loading of the embedded policy bundle on a
+// Compiler built directly via NewCompiler, as opposed to through a
+// ConfChecker (which exposes its own WithNoEmbedded). Used by `ccheck
+// test`, which should only compile and score coverage against the user's
+// own policyDir.
+func WithNoEmbeddedBundle(noEmbedded bool) CompilerOption {
+	return withNoEmbedded(noEmbedded)
+}
+
+// withEmbeddedNamespaces restricts which embedded packages are loaded.
+func withEmbeddedNamespaces(namespaces []string) CompilerOption {
+	return func(c *Compiler) {
+		c.embeddedNamespaces = namespaces
+	}
+}
+
+// withRefresh forces remote policy sources to be re-fetched rather than
+// served from cache.
+func withRefresh(refresh bool) CompilerOption {
+	return func(c *Compiler) {
+		c.refresh = refresh
+	}
+}
+
+// withPubKey verifies fetched remote bundles against pubkey before trusting
+// them.
+func withPubKey(pubkey string) CompilerOption {
+	return func(c *Compiler) {
+		c.pubkey = pubkey
+	}
 }
 
 // NewCompiler instantiates a new instance of a Compiler given the policies
-// defined in `policyDir`. To prepare the compiler you should run `.Build`
-func NewCompiler(policyDir string) *Compiler {
-	return &Compiler{
-		policyDir: policyDir,
+// resolved from policySources. To prepare the compiler you should run
+// `.Build`.
+func NewCompiler(policySources []string, opts ...CompilerOption) *Compiler {
+	c := &Compiler{
+		policySources: policySources,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// sourceOpts translates the Compiler's remote-fetching configuration into
+// pkg/sources options.
+func (c *Compiler) sourceOpts() []sources.Option {
+	return []sources.Option{
+		sources.WithRefresh(c.refresh),
+		sources.WithPubKey(c.pubkey),
+	}
+}
+
+// readUserPolicies resolves every configured policy source to a local
+// directory and reads the *.rego files out of it. Later sources win on name
+// collision, so a source list acts as an overlay, most-specific last.
+func (c *Compiler) readUserPolicies(ctx context.Context) (map[string]*ast.Module, error) {
+	ms := map[string]*ast.Module{}
+
+	for _, src := range c.policySources {
+		dir, err := sources.Resolve(ctx, src, c.sourceOpts()...)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving policy source %s: %s", src, err)
+		}
+
+		dms, err := c.readPolicies(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(dms) == 0 && sources.IsRemote(src) {
+			return nil, fmt.Errorf("policy source %s resolved to %s, which contains no .rego files", src, dir)
+		}
+
+		for name, m := range dms {
+			ms[name] = m
+		}
 	}
+
+	return ms, nil
 }
 
+// readPolicies reads the *.rego files at pPath into parsed modules, keyed by
+// their path relative to pPath (or just their base name, for a single file).
+// A remote source -- an extracted bundle tarball, a git clone, an ORAS pull
+// -- conventionally nests policies under subdirectories (e.g.
+// "policies/kubernetes/deny.rego"), so pPath is walked recursively rather
+// than read as a single flat directory.
 func (c *Compiler) readPolicies(pPath string) (map[string]*ast.Module, error) {
-	// double check that path exists
+	// double check that path exists -- an absent policyDir is not fatal since
+	// the embedded bundle may be all that's needed
 	info, err := os.Stat(pPath)
+	if os.IsNotExist(err) {
+		return map[string]*ast.Module{}, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error loading policies from %s: %s", pPath, err)
 	}
 
-	files := []os.FileInfo{info}
-	dirPath := filepath.Dir(pPath)
+	if !info.IsDir() {
+		if !strings.HasSuffix(pPath, ".rego") {
+			return map[string]*ast.Module{}, nil
+		}
 
-	if info.IsDir() {
-		files, err = ioutil.ReadDir(pPath)
+		name := filepath.Base(pPath)
+		parsed, err := parseRegoFile(pPath, name)
 		if err != nil {
-			return nil, fmt.Errorf("error loading policies from %s: %s", pPath, err)
+			return nil, err
 		}
-		dirPath = pPath
+
+		return map[string]*ast.Module{name: parsed}, nil
 	}
 
 	ms := map[string]*ast.Module{}
 
-	for _, file := range files {
-		// only consider rego files
-		if !strings.HasSuffix(file.Name(), ".rego") {
+	err = filepath.WalkDir(pPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".rego") {
+			return nil
+		}
+
+		name, err := filepath.Rel(pPath, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+
+		parsed, err := parseRegoFile(path, name)
+		if err != nil {
+			return err
+		}
+
+		ms[name] = parsed
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading policies from %s: %s", pPath, err)
+	}
+
+	return ms, nil
+}
+
+// parseRegoFile reads and parses the .rego file at path, naming the module
+// name for error messages and as the key policies are overlaid by.
+func parseRegoFile(path, name string) (*ast.Module, error) {
+	out, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.ParseModuleWithOpts(name, string(out), ast.ParserOptions{ProcessAnnotation: true})
+}
+
+// readEmbeddedPolicies parses the *.rego files built into the ccheck binary,
+// optionally filtered down to the packages named in c.embeddedNamespaces.
+func (c *Compiler) readEmbeddedPolicies() (map[string]*ast.Module, error) {
+	entries, err := fs.ReadDir(embeddedPolicies, embeddedPoliciesDir)
+	if err != nil {
+		return nil, fmt.Errorf("error loading embedded policies: %s", err)
+	}
+
+	ms := map[string]*ast.Module{}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".rego") {
 			continue
 		}
 
-		// choke on being unable to read a valid .rego file
-		out, err := ioutil.ReadFile(dirPath + "/" + file.Name())
+		out, err := embeddedPolicies.ReadFile(embeddedPoliciesDir + "/" + entry.Name())
 		if err != nil {
 			return nil, err
 		}
 
-		parsed, err := ast.ParseModule(file.Name(), string(out[:]))
+		parsed, err := ast.ParseModuleWithOpts(entry.Name(), string(out), ast.ParserOptions{ProcessAnnotation: true})
 		if err != nil {
 			return nil, err
 		}
 
-		ms[file.Name()] = parsed
+		if !c.embeddedNamespaceAllowed(parsed) {
+			continue
+		}
+
+		ms[entry.Name()] = parsed
 	}
 
 	return ms, nil
 }
 
-// Build bootstraps the compiler by reading all of the supplied policy definitions.
-func (c *Compiler) Build() error {
-	// load policy definitions
-	ps, err := c.readPolicies(c.policyDir)
+// embeddedNamespaceAllowed reports whether m's package should be loaded given
+// c.embeddedNamespaces. An empty filter allows every package.
+func (c *Compiler) embeddedNamespaceAllowed(m *ast.Module) bool {
+	if len(c.embeddedNamespaces) == 0 {
+		return true
+	}
+
+	pkg := m.Package.Path.String()
+	for _, ns := range c.embeddedNamespaces {
+		if pkg == "data."+ns || strings.HasPrefix(pkg, "data."+ns+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readSchemas builds a SchemaSet from the JSON Schema documents in a
+// "schemas" directory alongside the first local policy source, keyed by
+// filename (sans extension) under the `schema` root, e.g.
+// "schemas/kubernetes.json" becomes the schema referenced by rego
+// annotations as `schema.kubernetes`. A missing schemas directory is not an
+// error -- schemas are optional, and remote-only sources have no adjacent
+// directory to look in.
+func (c *Compiler) readSchemas() (*ast.SchemaSet, error) {
+	localDir := ""
+	for _, src := range c.policySources {
+		if !sources.IsRemote(src) {
+			localDir = strings.TrimPrefix(src, "file://")
+			break
+		}
+	}
+
+	if localDir == "" {
+		return nil, nil
+	}
+
+	schemaDir := filepath.Join(filepath.Dir(localDir), "schemas")
+
+	info, err := os.Stat(schemaDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading schemas from %s: %s", schemaDir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", schemaDir)
+	}
+
+	files, err := ioutil.ReadDir(schemaDir)
+	if err != nil {
+		return nil, fmt.Errorf("error loading schemas from %s: %s", schemaDir, err)
+	}
+
+	ss := ast.NewSchemaSet()
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		out, err := ioutil.ReadFile(filepath.Join(schemaDir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		var raw interface{}
+		if err := json.Unmarshal(out, &raw); err != nil {
+			return nil, fmt.Errorf("error parsing schema %s: %s", file.Name(), err)
+		}
+
+		name := strings.TrimSuffix(file.Name(), ".json")
+		path, err := ast.ParseRef("schema." + name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema name %s: %s", file.Name(), err)
+		}
+
+		ss.Put(path, raw)
+	}
+
+	return ss, nil
+}
+
+// Build bootstraps the compiler by resolving and reading all of the
+// supplied policy sources, overlaying them on top of the embedded policy
+// bundle (unless disabled via withNoEmbedded). Policy sources win on name
+// collision, most-specific (last) source wins.
+func (c *Compiler) Build(ctx context.Context) error {
+	ps := map[string]*ast.Module{}
+
+	if !c.noEmbedded {
+		eps, err := c.readEmbeddedPolicies()
+		if err != nil {
+			return err
+		}
+
+		for name, m := range eps {
+			ps[name] = m
+		}
+	}
+
+	// load user-supplied policy definitions -- these win on name collision
+	ups, err := c.readUserPolicies(ctx)
 	if err != nil {
 		return err
 	}
 
-	a := ast.NewCompiler()
+	for name, m := range ups {
+		ps[name] = m
+	}
+
+	if len(ps) == 0 {
+		return fmt.Errorf("no policies found in %v or the embedded bundle", c.policySources)
+	}
+
+	ss, err := c.readSchemas()
+	if err != nil {
+		return err
+	}
+
+	a := ast.NewCompiler().
+		WithUseTypeCheckAnnotations(true).
+		WithCapabilities(ast.CapabilitiesForThisVersion())
+	if ss != nil {
+		a = a.WithSchemas(ss)
+	}
+
 	a.Compile(ps)
 	if a.Failed() {
 		// check if compilation failed -- if so return errors
@@ -0,0 +1,50 @@
+package parsers
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/moby/buildkit/frontend/dockerfile/parser"
+)
+
+func init() {
+	Register("Dockerfile", dockerfileParser{})
+}
+
+// dockerfileParser handles Dockerfiles, flattening their instructions into
+// an array of {cmd, value, flags} objects in source order.
+type dockerfileParser struct{}
+
+func (dockerfileParser) Split(data []byte) [][]byte {
+	return singleDocument(data)
+}
+
+func (dockerfileParser) Unmarshal(data []byte, v interface{}) error {
+	result, err := parser.Parse(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("unable to parse Dockerfile: %s", err)
+	}
+
+	var instructions []interface{}
+	for _, child := range result.AST.Children {
+		instructions = append(instructions, map[string]interface{}{
+			"cmd":   strings.ToLower(child.Value),
+			"value": dockerfileArgs(child),
+			"flags": child.Flags,
+		})
+	}
+
+	return assign(v, instructions)
+}
+
+// dockerfileArgs joins an instruction's argument chain back into a single
+// string, e.g. `FROM golang:1.18 AS build` yields "golang:1.18 AS build".
+func dockerfileArgs(n *parser.Node) string {
+	var parts []string
+	for c := n.Next; c != nil; c = c.Next {
+		parts = append(parts, c.Value)
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,57 @@
+package parsers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDockerfileParserUnmarshal(t *testing.T) {
+	data := []byte(`FROM golang:1.18 AS build
+RUN go build ./...
+EXPOSE 8080
+`)
+
+	var out interface{}
+	if err := (dockerfileParser{}).Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %s", err)
+	}
+
+	instructions, ok := out.([]interface{})
+	if !ok {
+		t.Fatalf("got %T, want []interface{}", out)
+	}
+
+	if len(instructions) != 3 {
+		t.Fatalf("got %d instructions, want 3", len(instructions))
+	}
+
+	want := map[string]interface{}{
+		"cmd":   "from",
+		"value": "golang:1.18 AS build",
+		"flags": []string{},
+	}
+	if !reflect.DeepEqual(instructions[0], want) {
+		t.Fatalf("got %#v, want %#v", instructions[0], want)
+	}
+
+	run := instructions[1].(map[string]interface{})
+	if run["cmd"] != "run" || run["value"] != "go build ./..." {
+		t.Fatalf("got %#v, want cmd=run value=\"go build ./...\"", run)
+	}
+}
+
+func TestDockerfileParserUnmarshalError(t *testing.T) {
+	var out interface{}
+	if err := (dockerfileParser{}).Unmarshal([]byte(``), &out); err == nil {
+		t.Fatal("expected error for a Dockerfile with no instructions, got nil")
+	}
+}
+
+func TestDockerfileParserSplit(t *testing.T) {
+	data := []byte(`FROM golang:1.18`)
+
+	got := (dockerfileParser{}).Split(data)
+	if len(got) != 1 || string(got[0]) != string(data) {
+		t.Fatalf("got %v, want a single document containing the whole input", got)
+	}
+}
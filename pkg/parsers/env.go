@@ -0,0 +1,36 @@
+package parsers
+
+import "strings"
+
+func init() {
+	Register(".env", envParser{})
+}
+
+// envParser handles .env files of KEY=VALUE lines, ignoring blank lines,
+// "#" comments, and an optional leading "export ".
+type envParser struct{}
+
+func (envParser) Split(data []byte) [][]byte {
+	return singleDocument(data)
+}
+
+func (envParser) Unmarshal(data []byte, v interface{}) error {
+	doc := map[string]interface{}{}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+
+		doc[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(val), `"'`)
+	}
+
+	return assign(v, doc)
+}
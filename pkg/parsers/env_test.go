@@ -0,0 +1,56 @@
+package parsers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEnvParserUnmarshal(t *testing.T) {
+	data := []byte(`
+# a comment
+export FOO=bar
+BAZ="quoted value"
+QUUX='single quoted'
+
+EMPTY_OK=
+`)
+
+	var out interface{}
+	if err := (envParser{}).Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %s", err)
+	}
+
+	want := map[string]interface{}{
+		"FOO":      "bar",
+		"BAZ":      "quoted value",
+		"QUUX":     "single quoted",
+		"EMPTY_OK": "",
+	}
+
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("got %#v, want %#v", out, want)
+	}
+}
+
+func TestEnvParserUnmarshalIgnoresMalformedLines(t *testing.T) {
+	data := []byte("not-a-kv-pair\nFOO=bar\n")
+
+	var out interface{}
+	if err := (envParser{}).Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %s", err)
+	}
+
+	want := map[string]interface{}{"FOO": "bar"}
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("got %#v, want %#v", out, want)
+	}
+}
+
+func TestEnvParserSplit(t *testing.T) {
+	data := []byte(`FOO=bar`)
+
+	got := (envParser{}).Split(data)
+	if len(got) != 1 || string(got[0]) != string(data) {
+		t.Fatalf("got %v, want a single document containing the whole input", got)
+	}
+}
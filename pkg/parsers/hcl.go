@@ -0,0 +1,97 @@
+package parsers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+func init() {
+	Register(".tf", hclParser{})
+	Register(".hcl", hclParser{})
+}
+
+// hclParser handles Terraform/HCL files, flattening them into a
+// JSON-compatible tree: attributes become map entries, and each block
+// becomes a nested map keyed by its type and then each of its labels in
+// turn -- the same shape Terraform's own JSON syntax uses, e.g.
+// `resource "aws_s3_bucket" "data" {...}` becomes
+// resource.aws_s3_bucket.data.
+type hclParser struct{}
+
+func (hclParser) Split(data []byte) [][]byte {
+	return singleDocument(data)
+}
+
+func (hclParser) Unmarshal(data []byte, v interface{}) error {
+	f, diags := hclparse.NewParser().ParseHCL(data, "input.hcl")
+	if diags.HasErrors() {
+		return fmt.Errorf("unable to parse hcl: %s", diags)
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return fmt.Errorf("unable to parse hcl: unexpected body type %T", f.Body)
+	}
+
+	return assign(v, hclBodyToMap(body, data))
+}
+
+func hclBodyToMap(body *hclsyntax.Body, data []byte) map[string]interface{} {
+	out := map[string]interface{}{}
+
+	for name, attr := range body.Attributes {
+		out[name] = hclAttrValue(attr, data)
+	}
+
+	for _, block := range body.Blocks {
+		path := append([]string{block.Type}, block.Labels...)
+		setBlockPath(out, path, hclBodyToMap(block.Body, data))
+	}
+
+	return out
+}
+
+// setBlockPath nests val under path within m, creating intermediate maps as
+// needed.
+func setBlockPath(m map[string]interface{}, path []string, val map[string]interface{}) {
+	if len(path) == 1 {
+		m[path[0]] = val
+		return
+	}
+
+	next, ok := m[path[0]].(map[string]interface{})
+	if !ok {
+		next = map[string]interface{}{}
+		m[path[0]] = next
+	}
+
+	setBlockPath(next, path[1:], val)
+}
+
+// hclAttrValue evaluates attr in an empty context. Expressions that
+// reference variables or functions we can't resolve standalone (e.g.
+// var.foo) aren't wholly known, so we fall back to their literal source
+// text rather than failing the whole file.
+func hclAttrValue(attr *hclsyntax.Attribute, data []byte) interface{} {
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !val.IsWhollyKnown() {
+		return strings.TrimSpace(string(attr.Expr.Range().SliceBytes(data)))
+	}
+
+	raw, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return nil
+	}
+
+	var out interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+
+	return out
+}
@@ -0,0 +1,81 @@
+package parsers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHCLParserUnmarshal(t *testing.T) {
+	data := []byte(`
+resource "aws_s3_bucket" "data" {
+  bucket = "my-bucket"
+  count  = 2
+
+  versioning {
+    enabled = true
+  }
+}
+`)
+
+	var out interface{}
+	if err := (hclParser{}).Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %s", err)
+	}
+
+	want := map[string]interface{}{
+		"resource": map[string]interface{}{
+			"aws_s3_bucket": map[string]interface{}{
+				"data": map[string]interface{}{
+					"bucket": "my-bucket",
+					"count":  float64(2),
+					"versioning": map[string]interface{}{
+						"enabled": true,
+					},
+				},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("got %#v, want %#v", out, want)
+	}
+}
+
+func TestHCLParserUnmarshalUnresolvedExpression(t *testing.T) {
+	data := []byte(`
+resource "aws_instance" "web" {
+  ami = var.ami_id
+}
+`)
+
+	var out interface{}
+	if err := (hclParser{}).Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %s", err)
+	}
+
+	doc, ok := out.(map[string]interface{})
+	if !ok {
+		t.Fatalf("got %T, want map[string]interface{}", out)
+	}
+
+	ami := doc["resource"].(map[string]interface{})["aws_instance"].(map[string]interface{})["web"].(map[string]interface{})["ami"]
+	if ami != "var.ami_id" {
+		t.Fatalf("ami = %#v, want fallback source text %q", ami, "var.ami_id")
+	}
+}
+
+func TestHCLParserUnmarshalError(t *testing.T) {
+	var out interface{}
+	if err := (hclParser{}).Unmarshal([]byte(`resource "x" "y" {`), &out); err == nil {
+		t.Fatal("expected error for malformed hcl, got nil")
+	}
+}
+
+func TestHCLParserSplit(t *testing.T) {
+	data := []byte(`resource "x" "y" {}`)
+
+	got := (hclParser{}).Split(data)
+	if len(got) != 1 || string(got[0]) != string(data) {
+		t.Fatalf("got %v, want a single document containing the whole input", got)
+	}
+}
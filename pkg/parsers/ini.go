@@ -0,0 +1,33 @@
+package parsers
+
+import (
+	"fmt"
+
+	"gopkg.in/ini.v1"
+)
+
+func init() {
+	Register(".ini", iniParser{})
+}
+
+// iniParser handles INI files, keyed by section name (ini.DefaultSection,
+// "DEFAULT", for keys that precede any section header).
+type iniParser struct{}
+
+func (iniParser) Split(data []byte) [][]byte {
+	return singleDocument(data)
+}
+
+func (iniParser) Unmarshal(data []byte, v interface{}) error {
+	f, err := ini.Load(data)
+	if err != nil {
+		return fmt.Errorf("unable to parse ini: %s", err)
+	}
+
+	doc := map[string]interface{}{}
+	for _, section := range f.Sections() {
+		doc[section.Name()] = section.KeysHash()
+	}
+
+	return assign(v, doc)
+}
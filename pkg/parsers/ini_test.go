@@ -0,0 +1,53 @@
+package parsers
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/ini.v1"
+)
+
+func TestINIParserUnmarshal(t *testing.T) {
+	data := []byte(`
+env = production
+
+[server]
+host = localhost
+port = 8080
+`)
+
+	var out interface{}
+	if err := (iniParser{}).Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %s", err)
+	}
+
+	want := map[string]interface{}{
+		ini.DefaultSection: map[string]string{
+			"env": "production",
+		},
+		"server": map[string]string{
+			"host": "localhost",
+			"port": "8080",
+		},
+	}
+
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("got %#v, want %#v", out, want)
+	}
+}
+
+func TestINIParserUnmarshalError(t *testing.T) {
+	var out interface{}
+	if err := (iniParser{}).Unmarshal([]byte("[section\nkey = value"), &out); err == nil {
+		t.Fatal("expected error for malformed ini, got nil")
+	}
+}
+
+func TestINIParserSplit(t *testing.T) {
+	data := []byte(`key = value`)
+
+	got := (iniParser{}).Split(data)
+	if len(got) != 1 || string(got[0]) != string(data) {
+		t.Fatalf("got %v, want a single document containing the whole input", got)
+	}
+}
@@ -1,6 +1,9 @@
+// Package parsers turns config files of various formats into the generic
+// Go values ccheck evaluates policies against.
 package parsers
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"path/filepath"
@@ -8,28 +11,98 @@ import (
 	"github.com/ghodss/yaml"
 )
 
-// Parser is the interface implemented by objects that can unmarshal
-// bytes into a golang interface.
-type Parser = func([]byte, interface{}) error
+// Parser turns a config file's raw bytes into the distinct "documents"
+// ccheck evaluates against policy, and unmarshals each document into a
+// generic Go value.
+type Parser interface {
+	// Split breaks a file's raw bytes into its distinct documents, e.g.
+	// YAML's "---" document separator. Formats with no such concept return
+	// the whole file as a single document.
+	Split(data []byte) [][]byte
 
+	// Unmarshal parses a single document's bytes into v.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// registry maps a file extension (e.g. ".yaml") or, for extension-less
+// formats like Dockerfile, an exact base filename (e.g. "Dockerfile") to the
+// Parser that handles it.
+var registry = map[string]Parser{}
+
+// Register adds or replaces the Parser used for files matching key, which
+// is either an extension including its leading dot, or an exact base
+// filename for formats that aren't identified by extension.
+func Register(key string, p Parser) {
+	registry[key] = p
+}
+
+// Get returns the Parser registered for fileName, matched first against its
+// base filename and then against its extension.
 func Get(fileName string) (Parser, error) {
-	suffix := filepath.Ext(fileName)
+	if p, ok := registry[filepath.Base(fileName)]; ok {
+		return p, nil
+	}
 
-	switch suffix {
-	case ".yaml", ".yml", ".json":
-		return parseYAML, nil
+	if p, ok := registry[filepath.Ext(fileName)]; ok {
+		return p, nil
+	}
 
-		// TODO (brendanjryan) add more parsers
-	default:
-		return nil, errors.New("unable to find Parser for file: " + fileName)
+	return nil, errors.New("unable to find Parser for file: " + fileName)
+}
+
+// assign stores val through v, which must be a *interface{} (config input is
+// parsed into a `var input interface{}`). Any other target, such as the
+// *map[string]interface{} that pkg's data and values files are parsed into,
+// is an error.
+func assign(v interface{}, val interface{}) error {
+	ptr, ok := v.(*interface{})
+	if !ok {
+		return fmt.Errorf("parsers: unsupported unmarshal target %T", v)
 	}
+
+	*ptr = val
+	return nil
+}
+
+// singleDocument is the Split implementation for formats with no concept of
+// multiple documents per file.
+func singleDocument(data []byte) [][]byte {
+	return [][]byte{data}
 }
 
-func parseYAML(bs []byte, v interface{}) error {
-	err := yaml.Unmarshal(bs, v)
-	if err != nil {
+func init() {
+	Register(".yaml", yamlParser{})
+	Register(".yml", yamlParser{})
+	Register(".json", jsonParser{})
+}
+
+// yamlParser handles YAML, including files containing multiple "---"
+// separated documents.
+type yamlParser struct{}
+
+func (yamlParser) Split(data []byte) [][]byte {
+	return bytes.Split(data, []byte("\n---\n"))
+}
+
+func (yamlParser) Unmarshal(data []byte, v interface{}) error {
+	if err := yaml.Unmarshal(data, v); err != nil {
 		return fmt.Errorf("unable to parse yaml: %s", err)
 	}
 
 	return nil
 }
+
+// jsonParser handles JSON, which has no "---"-style multi-document concept.
+type jsonParser struct{}
+
+func (jsonParser) Split(data []byte) [][]byte {
+	return singleDocument(data)
+}
+
+func (jsonParser) Unmarshal(data []byte, v interface{}) error {
+	if err := yaml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("unable to parse json: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package parsers
+
+import "testing"
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		fileName string
+		wantType Parser
+	}{
+		{"config.yaml", yamlParser{}},
+		{"config.yml", yamlParser{}},
+		{"config.json", jsonParser{}},
+		{"main.tf", hclParser{}},
+		{"variables.hcl", hclParser{}},
+		{"Dockerfile", dockerfileParser{}},
+		{"app.toml", tomlParser{}},
+		{"settings.ini", iniParser{}},
+		{".env", envParser{}},
+	}
+
+	for _, tt := range tests {
+		p, err := Get(tt.fileName)
+		if err != nil {
+			t.Errorf("Get(%q) returned error: %s", tt.fileName, err)
+			continue
+		}
+
+		if p != tt.wantType {
+			t.Errorf("Get(%q) = %T, want %T", tt.fileName, p, tt.wantType)
+		}
+	}
+}
+
+func TestGetUnknownExtension(t *testing.T) {
+	if _, err := Get("config.unknown"); err == nil {
+		t.Fatal("expected error for unregistered extension, got nil")
+	}
+}
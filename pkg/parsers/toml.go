@@ -0,0 +1,27 @@
+package parsers
+
+import (
+	"fmt"
+
+	"github.com/pelletier/go-toml"
+)
+
+func init() {
+	Register(".toml", tomlParser{})
+}
+
+// tomlParser handles TOML files.
+type tomlParser struct{}
+
+func (tomlParser) Split(data []byte) [][]byte {
+	return singleDocument(data)
+}
+
+func (tomlParser) Unmarshal(data []byte, v interface{}) error {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("unable to parse toml: %s", err)
+	}
+
+	return assign(v, doc)
+}
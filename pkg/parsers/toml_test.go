@@ -0,0 +1,51 @@
+package parsers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTOMLParserUnmarshal(t *testing.T) {
+	data := []byte(`
+name = "ccheck"
+count = 3
+
+[server]
+host = "localhost"
+port = 8080
+`)
+
+	var out interface{}
+	if err := (tomlParser{}).Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal returned error: %s", err)
+	}
+
+	want := map[string]interface{}{
+		"name":  "ccheck",
+		"count": int64(3),
+		"server": map[string]interface{}{
+			"host": "localhost",
+			"port": int64(8080),
+		},
+	}
+
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("got %#v, want %#v", out, want)
+	}
+}
+
+func TestTOMLParserUnmarshalError(t *testing.T) {
+	var out interface{}
+	if err := (tomlParser{}).Unmarshal([]byte(`name = `), &out); err == nil {
+		t.Fatal("expected error for malformed toml, got nil")
+	}
+}
+
+func TestTOMLParserSplit(t *testing.T) {
+	data := []byte(`name = "ccheck"`)
+
+	got := (tomlParser{}).Split(data)
+	if len(got) != 1 || string(got[0]) != string(data) {
+		t.Fatalf("got %v, want a single document containing the whole input", got)
+	}
+}
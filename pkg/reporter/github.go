@@ -0,0 +1,33 @@
+package reporter
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/brendanjryan/ccheck/pkg"
+)
+
+// github renders findings as GitHub Actions workflow commands
+// (https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions)
+// so they're annotated directly on the diff in a pull request.
+type github struct {
+	w io.Writer
+}
+
+func newGitHub(w io.Writer) *github {
+	return &github{w: w}
+}
+
+func (g *github) Ok(file string) {}
+
+func (g *github) Warning(file string, f pkg.Finding) {
+	fmt.Fprintf(g.w, "::warning file=%s::%s\n", file, f.Message)
+}
+
+func (g *github) Err(file string, f pkg.Finding) {
+	fmt.Fprintf(g.w, "::error file=%s::%s\n", file, f.Message)
+}
+
+func (g *github) Flush() error {
+	return nil
+}
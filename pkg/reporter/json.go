@@ -0,0 +1,64 @@
+package reporter
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/brendanjryan/ccheck/pkg"
+)
+
+// fileResult is the machine-readable rendering of a single file's
+// CheckResult.
+type fileResult struct {
+	File     string        `json:"file"`
+	Passed   bool          `json:"passed"`
+	Warnings []pkg.Finding `json:"warnings,omitempty"`
+	Failures []pkg.Finding `json:"failures,omitempty"`
+}
+
+// jsonReporter buffers every file's results and writes them as a single
+// JSON array on Flush.
+type jsonReporter struct {
+	w       io.Writer
+	results map[string]*fileResult
+	order   []string
+}
+
+func newJSON(w io.Writer) *jsonReporter {
+	return &jsonReporter{w: w, results: map[string]*fileResult{}}
+}
+
+func (j *jsonReporter) entry(file string) *fileResult {
+	e, ok := j.results[file]
+	if !ok {
+		e = &fileResult{File: file, Passed: true}
+		j.results[file] = e
+		j.order = append(j.order, file)
+	}
+
+	return e
+}
+
+func (j *jsonReporter) Ok(file string) {
+	j.entry(file)
+}
+
+func (j *jsonReporter) Warning(file string, f pkg.Finding) {
+	e := j.entry(file)
+	e.Warnings = append(e.Warnings, f)
+}
+
+func (j *jsonReporter) Err(file string, f pkg.Finding) {
+	e := j.entry(file)
+	e.Passed = false
+	e.Failures = append(e.Failures, f)
+}
+
+func (j *jsonReporter) Flush() error {
+	out := make([]*fileResult, 0, len(j.order))
+	for _, name := range j.order {
+		out = append(out, j.results[name])
+	}
+
+	return json.NewEncoder(j.w).Encode(out)
+}
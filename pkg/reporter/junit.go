@@ -0,0 +1,85 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/brendanjryan/ccheck/pkg"
+)
+
+type junitSuite struct {
+	XMLName   xml.Name    `xml:"testsuite"`
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	TestCases []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name    string         `xml:"name,attr"`
+	Failure []junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// junit buffers each file as a testcase so CI systems that ingest JUnit
+// reports can surface ccheck failures the same way they do test failures.
+// ccheck's warnings have no JUnit equivalent and are not reported as
+// failures.
+type junit struct {
+	w     io.Writer
+	cases map[string]*junitCase
+	order []string
+}
+
+func newJUnit(w io.Writer) *junit {
+	return &junit{w: w, cases: map[string]*junitCase{}}
+}
+
+func (j *junit) entry(file string) *junitCase {
+	c, ok := j.cases[file]
+	if !ok {
+		c = &junitCase{Name: file}
+		j.cases[file] = c
+		j.order = append(j.order, file)
+	}
+
+	return c
+}
+
+func (j *junit) Ok(file string) {
+	j.entry(file)
+}
+
+func (j *junit) Warning(file string, f pkg.Finding) {
+	j.entry(file)
+}
+
+func (j *junit) Err(file string, f pkg.Finding) {
+	c := j.entry(file)
+	c.Failure = append(c.Failure, junitFailure{Message: f.Message, Content: f.String()})
+}
+
+func (j *junit) Flush() error {
+	suite := junitSuite{Name: "ccheck"}
+	for _, name := range j.order {
+		suite.TestCases = append(suite.TestCases, *j.cases[name])
+	}
+	suite.Tests = len(suite.TestCases)
+	for _, c := range suite.TestCases {
+		if len(c.Failure) > 0 {
+			suite.Failures++
+		}
+	}
+
+	if _, err := io.WriteString(j.w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(j.w)
+	enc.Indent("", "  ")
+	return enc.Encode(suite)
+}
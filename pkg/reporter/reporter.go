@@ -0,0 +1,40 @@
+// Package reporter renders the results of a ConfChecker run in the format
+// requested via ccheck's --format/-f flag.
+package reporter
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/brendanjryan/ccheck/pkg"
+)
+
+// Reporter renders CheckResults as they're produced. Ok is called once per
+// passing file; Warning and Err are called once per pkg.Finding. Flush is
+// called once every file has been reported and should write out anything
+// the Reporter buffered.
+type Reporter interface {
+	Ok(file string)
+	Warning(file string, f pkg.Finding)
+	Err(file string, f pkg.Finding)
+	Flush() error
+}
+
+// New constructs the Reporter registered under name, writing to w. An empty
+// name selects the default colorized text format.
+func New(name string, w io.Writer) (Reporter, error) {
+	switch name {
+	case "", "text":
+		return newText(w), nil
+	case "json":
+		return newJSON(w), nil
+	case "sarif":
+		return newSARIF(w), nil
+	case "junit":
+		return newJUnit(w), nil
+	case "github":
+		return newGitHub(w), nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", name)
+	}
+}
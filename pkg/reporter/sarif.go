@@ -0,0 +1,124 @@
+package reporter
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/brendanjryan/ccheck/pkg"
+)
+
+// sarifSchema is the SARIF 2.1.0 schema URI, so results can be uploaded to
+// GitHub code scanning or Azure DevOps.
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	HelpURI          string       `json:"helpUri,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarif buffers findings across every file and emits a single SARIF 2.1.0
+// log on Flush, with a rules list derived from the Finding metadata (rule
+// IDs and titles sourced from rego annotations).
+type sarif struct {
+	w       io.Writer
+	rules   map[string]sarifRule
+	results []sarifResult
+}
+
+func newSARIF(w io.Writer) *sarif {
+	return &sarif{w: w, rules: map[string]sarifRule{}}
+}
+
+func (s *sarif) Ok(file string) {}
+
+func (s *sarif) Warning(file string, f pkg.Finding) {
+	s.record(file, f, "warning")
+}
+
+func (s *sarif) Err(file string, f pkg.Finding) {
+	s.record(file, f, "error")
+}
+
+func (s *sarif) record(file string, f pkg.Finding, level string) {
+	if _, ok := s.rules[f.RuleID]; !ok {
+		s.rules[f.RuleID] = sarifRule{
+			ID:               f.RuleID,
+			ShortDescription: sarifMessage{Text: f.Title},
+		}
+	}
+
+	s.results = append(s.results, sarifResult{
+		RuleID:  f.RuleID,
+		Level:   level,
+		Message: sarifMessage{Text: f.Message},
+		Locations: []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: file},
+			},
+		}},
+	})
+}
+
+func (s *sarif) Flush() error {
+	rules := make([]sarifRule, 0, len(s.rules))
+	for _, r := range s.rules {
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	doc := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "ccheck", Rules: rules}},
+			Results: s.results,
+		}},
+	}
+
+	return json.NewEncoder(s.w).Encode(doc)
+}
@@ -0,0 +1,33 @@
+package reporter
+
+import (
+	"io"
+
+	"github.com/brendanjryan/ccheck/pkg"
+	"github.com/fatih/color"
+)
+
+// text is the default, colorized Reporter ccheck has always printed.
+type text struct {
+	w io.Writer
+}
+
+func newText(w io.Writer) *text {
+	return &text{w: w}
+}
+
+func (t *text) Ok(file string) {
+	color.New(color.FgGreen).Fprintf(t.w, "Passed: %s\n", file)
+}
+
+func (t *text) Warning(file string, f pkg.Finding) {
+	color.New(color.FgYellow).Fprintf(t.w, "Warning: %s - %s\n", file, f)
+}
+
+func (t *text) Err(file string, f pkg.Finding) {
+	color.New(color.FgRed).Fprintf(t.w, "Failure: %s - %s\n", file, f)
+}
+
+func (t *text) Flush() error {
+	return nil
+}
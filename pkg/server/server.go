@@ -0,0 +1,205 @@
+// Package server exposes ccheck's policy evaluation over HTTP, turning it
+// into an admission-webhook-style service suitable for CI pipelines or
+// Kubernetes validating webhooks, rather than a one-shot CLI.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/brendanjryan/ccheck/pkg"
+	"github.com/brendanjryan/ccheck/pkg/parsers"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Server compiles the policies in a directory once and serves HTTP requests
+// against them, recompiling on SIGHUP or on changes to the policy directory.
+type Server struct {
+	namespace string
+	policyDir string
+	timeout   time.Duration
+
+	mu       sync.RWMutex
+	compiler *pkg.Compiler
+}
+
+// New builds a Server for the policies in policyDir under namespace. Call
+// Build before Handler serves any traffic.
+func New(namespace, policyDir string, timeout time.Duration) *Server {
+	return &Server{
+		namespace: namespace,
+		policyDir: policyDir,
+		timeout:   timeout,
+	}
+}
+
+// Build (re)compiles the configured policies and swaps them in atomically,
+// so in-flight requests keep using the previous compiler until this returns.
+func (s *Server) Build(ctx context.Context) error {
+	c := pkg.NewCompiler([]string{s.policyDir})
+	if err := c.Build(ctx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.compiler = c
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *Server) currentCompiler() *pkg.Compiler {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.compiler
+}
+
+// Handler returns the http.Handler exposing POST /check, GET /healthz and
+// GET /policies.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/check", s.handleCheck)
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/policies", s.handlePolicies)
+	return mux
+}
+
+// WatchForReload recompiles policies whenever SIGHUP is received or the
+// policy directory changes on disk. It blocks until ctx is canceled.
+func (s *Server) WatchForReload(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error creating policy watcher: %s", err)
+	}
+	defer watcher.Close()
+
+	// policyDir is optional (the embedded bundle may be all that's loaded),
+	// so a missing directory only disables fsnotify-triggered reloads --
+	// SIGHUP-triggered reloads still work.
+	if err := watcher.Add(s.policyDir); err != nil {
+		log.Printf("not watching %s for changes: %s", s.policyDir, err)
+	}
+
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	reload := func() {
+		if err := s.Build(ctx); err != nil {
+			log.Println("error reloading policies: ", err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sighup:
+			reload()
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 {
+				reload()
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Println("policy watcher error: ", err)
+		}
+	}
+}
+
+// handleCheck evaluates the posted config against the compiled policies,
+// returning 422 with structured findings when a policy denies the input, or
+// 500 when evaluation itself fails (bad policies, query errors).
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	compiler := s.currentCompiler()
+	if compiler == nil {
+		http.Error(w, "policies not yet compiled", http.StatusServiceUnavailable)
+		return
+	}
+
+	filename := r.URL.Query().Get("filename")
+	if filename == "" {
+		filename = "input.yaml"
+	}
+
+	parse, err := parsers.Get(filename)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "error reading request body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var input interface{}
+	if err := parse.Unmarshal(body, &input); err != nil {
+		http.Error(w, "error parsing input: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.timeout)
+	defer cancel()
+
+	res, err := pkg.EvaluateInput(ctx, s.namespace, input, compiler.Compiler, nil)
+	if err != nil {
+		http.Error(w, "error evaluating policies: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if len(res.Failures) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	writeJSON(w, status, res)
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handlePolicies lists the names of every compiled rule module.
+func (s *Server) handlePolicies(w http.ResponseWriter, r *http.Request) {
+	compiler := s.currentCompiler()
+	if compiler == nil {
+		http.Error(w, "policies not yet compiled", http.StatusServiceUnavailable)
+		return
+	}
+
+	names := make([]string, 0, len(compiler.Modules))
+	for name := range compiler.Modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writeJSON(w, http.StatusOK, names)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,160 @@
+package sources
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// httpSource fetches a (signed) OPA bundle tarball over HTTP(S) and
+// extracts it into the cache.
+type httpSource struct {
+	uri string
+	cfg config
+}
+
+func (h *httpSource) Resolve(ctx context.Context) (string, error) {
+	dir := cacheDirFor(h.cfg, h.uri)
+	if cached(h.cfg, dir) {
+		return dir, nil
+	}
+
+	tmp, err := os.CreateTemp("", "ccheck-bundle-*.tar.gz")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if err := download(ctx, h.cfg.client, h.uri, tmp); err != nil {
+		return "", fmt.Errorf("error downloading bundle %s: %s", h.uri, err)
+	}
+
+	if h.cfg.pubkey != "" {
+		sigPath, err := fetchSignature(ctx, h.cfg, h.uri)
+		if err != nil {
+			return "", fmt.Errorf("error fetching signature for bundle %s: %s", h.uri, err)
+		}
+		defer os.Remove(sigPath)
+
+		if err := verifySignature(h.cfg.pubkey, tmp.Name(), sigPath); err != nil {
+			return "", fmt.Errorf("error verifying bundle %s: %s", h.uri, err)
+		}
+	}
+
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	if err := extractTarGz(tmp, dir); err != nil {
+		return "", fmt.Errorf("error extracting bundle %s: %s", h.uri, err)
+	}
+
+	return dir, nil
+}
+
+// fetchSignature downloads the detached signature published alongside the
+// bundle at uri (e.g. "<uri>.sig" for cosign) into a temp file, which the
+// caller is responsible for removing.
+func fetchSignature(ctx context.Context, cfg config, uri string) (string, error) {
+	tmp, err := os.CreateTemp("", "ccheck-bundle-*"+signatureSuffix(cfg.pubkey))
+	if err != nil {
+		return "", err
+	}
+	defer tmp.Close()
+
+	if err := download(ctx, cfg.client, uri+signatureSuffix(cfg.pubkey), tmp); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+func download(ctx context.Context, client *http.Client, uri string, dst io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	_, err = io.Copy(dst, resp.Body)
+	return err
+}
+
+// extractTarGz unpacks a gzip-compressed tarball into dir, creating it if
+// necessary.
+func extractTarGz(r io.Reader, dir string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dir, filepath.Clean(hdr.Name))
+		if !isWithinDir(dir, target) {
+			return fmt.Errorf("bundle entry %q escapes destination directory", hdr.Name)
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+			if err != nil {
+				return err
+			}
+
+			if _, err := io.Copy(f, tr); err != nil {
+				f.Close()
+				return err
+			}
+
+			f.Close()
+		}
+	}
+}
+
+func isWithinDir(dir, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
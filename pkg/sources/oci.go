@@ -0,0 +1,49 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ociSource pulls a bundle artifact from an OCI registry using the ORAS
+// CLI, the reference implementation of the ORAS protocol for storing
+// arbitrary artifacts in an OCI registry. Like git+https:// sources, this
+// delegates to an external binary rather than vendoring a registry client.
+type ociSource struct {
+	uri string
+	cfg config
+}
+
+func (o *ociSource) Resolve(ctx context.Context) (string, error) {
+	// --pubkey only fetches and checks a detached signature for httpSource;
+	// silently skipping it here would let a caller believe an oci:// bundle
+	// had been verified when it hasn't.
+	if o.cfg.pubkey != "" {
+		return "", errors.New("signature verification (--pubkey) is not supported for oci:// bundles")
+	}
+
+	dir := cacheDirFor(o.cfg, o.uri)
+	if cached(o.cfg, dir) {
+		return dir, nil
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	ref := strings.TrimPrefix(o.uri, "oci://")
+
+	cmd := exec.CommandContext(ctx, "oras", "pull", ref, "-o", dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("oras pull failed: %s: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return dir, nil
+}
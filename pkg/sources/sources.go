@@ -0,0 +1,135 @@
+// Package sources resolves policy bundle locations -- local directories,
+// HTTP(S) tarballs, OCI artifacts, and git repositories -- into a local
+// directory of *.rego files, so organizations can centrally publish
+// compliance policies and have individual repos pin to a versioned bundle
+// rather than vendoring .rego files everywhere.
+package sources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PolicySource resolves a single policy bundle URI to a local directory
+// containing *.rego files.
+type PolicySource interface {
+	Resolve(ctx context.Context) (string, error)
+}
+
+// config holds the options shared by every PolicySource.
+type config struct {
+	cacheDir string
+	refresh  bool
+	pubkey   string
+	client   *http.Client
+}
+
+// Option customizes how Resolve fetches and caches a policy bundle.
+type Option func(*config)
+
+// WithCacheDir overrides where fetched bundles are cached. Defaults to
+// $XDG_CACHE_HOME/ccheck (or $HOME/.cache/ccheck).
+func WithCacheDir(dir string) Option {
+	return func(c *config) { c.cacheDir = dir }
+}
+
+// WithRefresh forces a bundle to be re-fetched even if a cached copy exists.
+func WithRefresh(refresh bool) Option {
+	return func(c *config) { c.refresh = refresh }
+}
+
+// WithPubKey verifies the fetched bundle's signature against pubkey (a
+// cosign or minisign public key) before it's trusted.
+func WithPubKey(pubkey string) Option {
+	return func(c *config) { c.pubkey = pubkey }
+}
+
+// WithHTTPClient overrides the client used to fetch https:// bundles.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) { c.client = client }
+}
+
+// IsRemote reports whether uri names a remote source (https://, oci://, or
+// git+https://) as opposed to a plain local directory.
+func IsRemote(uri string) bool {
+	return strings.HasPrefix(uri, "https://") ||
+		strings.HasPrefix(uri, "http://") ||
+		strings.HasPrefix(uri, "oci://") ||
+		strings.HasPrefix(uri, "git+")
+}
+
+// Resolve fetches (if necessary) and returns the local directory backing
+// uri. A bare path or a file:// URI resolves to itself with no fetching or
+// caching.
+func Resolve(ctx context.Context, uri string, opts ...Option) (string, error) {
+	cfg := config{
+		cacheDir: defaultCacheDir(),
+		client:   http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	src := newSource(uri, cfg)
+	return src.Resolve(ctx)
+}
+
+func newSource(uri string, cfg config) PolicySource {
+	switch {
+	case strings.HasPrefix(uri, "git+"):
+		return &gitSource{uri: strings.TrimPrefix(uri, "git+"), cfg: cfg}
+	case strings.HasPrefix(uri, "oci://"):
+		return &ociSource{uri: uri, cfg: cfg}
+	case strings.HasPrefix(uri, "https://"), strings.HasPrefix(uri, "http://"):
+		return &httpSource{uri: uri, cfg: cfg}
+	case strings.HasPrefix(uri, "file://"):
+		return &fileSource{path: strings.TrimPrefix(uri, "file://")}
+	default:
+		return &fileSource{path: uri}
+	}
+}
+
+// fileSource is the original "just read a local directory" behavior.
+type fileSource struct {
+	path string
+}
+
+func (f *fileSource) Resolve(ctx context.Context) (string, error) {
+	return f.path, nil
+}
+
+// cacheDirFor returns the directory a remote bundle named by uri should be
+// cached under: $cacheDir/<sha256(uri)>.
+func cacheDirFor(cfg config, uri string) string {
+	sum := sha256.Sum256([]byte(uri))
+	return filepath.Join(cfg.cacheDir, hex.EncodeToString(sum[:]))
+}
+
+func defaultCacheDir() string {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "ccheck")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "ccheck")
+	}
+
+	return filepath.Join(home, ".cache", "ccheck")
+}
+
+// cached reports whether dir already holds a resolved bundle and a refresh
+// wasn't requested.
+func cached(cfg config, dir string) bool {
+	if cfg.refresh {
+		return false
+	}
+
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,48 @@
+package sources
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// signatureSuffix returns the conventional suffix a detached signature for
+// pubkey's verifier is published under: cosign expects "<artifact>.sig",
+// minisign "<artifact>.minisig".
+func signatureSuffix(pubkey string) string {
+	if strings.HasSuffix(pubkey, ".pub") {
+		return ".sig"
+	}
+
+	return ".minisig"
+}
+
+// verifySignature checks artifactPath's detached signature (already
+// downloaded to signaturePath) against pubkey, shelling out to whichever
+// verifier matches the key's convention: cosign for a ".pub"-suffixed key,
+// minisign otherwise. Both tools are the de facto standard for verifying
+// signed artifacts and are expected to already be on $PATH, the same way
+// git is expected for git+https:// sources.
+func verifySignature(pubkey, artifactPath, signaturePath string) error {
+	var cmd *exec.Cmd
+	if strings.HasSuffix(pubkey, ".pub") {
+		cmd = exec.Command("cosign", "verify-blob",
+			"--key", pubkey,
+			"--signature", signaturePath,
+			artifactPath,
+		)
+	} else {
+		cmd = exec.Command("minisign", "-V",
+			"-p", pubkey,
+			"-m", artifactPath,
+			"-x", signaturePath,
+		)
+	}
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("signature verification failed: %s: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
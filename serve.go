@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/brendanjryan/ccheck/pkg/server"
+	"github.com/urfave/cli"
+)
+
+// serveCommand builds the `ccheck serve` subcommand, which starts an HTTP
+// server exposing /check, /healthz and /policies so ccheck can run as a
+// long-lived validation service rather than a one-shot CLI.
+func serveCommand() cli.Command {
+	var policyDir, namespace, addr string
+	var timeout time.Duration
+
+	return cli.Command{
+		Name:  "serve",
+		Usage: "serve <flags> -- run ccheck as an HTTP service",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:        "p",
+				Value:       "policies",
+				Usage:       "directory which policy definitions live in",
+				Destination: &policyDir,
+			},
+			cli.StringFlag{
+				Name:        "n",
+				Value:       "main",
+				Usage:       "namespace of rules",
+				Destination: &namespace,
+			},
+			cli.StringFlag{
+				Name:        "addr",
+				Value:       ":8181",
+				Usage:       "address to listen on",
+				Destination: &addr,
+			},
+			cli.DurationFlag{
+				Name:        "timeout",
+				Value:       5 * time.Second,
+				Usage:       "per-request evaluation timeout",
+				Destination: &timeout,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s := server.New(namespace, policyDir, timeout)
+			if err := s.Build(ctx); err != nil {
+				return cli.NewExitError("error compiling policies: "+err.Error(), 1)
+			}
+
+			go func() {
+				if err := s.WatchForReload(ctx); err != nil {
+					log.Println("policy reload watcher stopped: ", err)
+				}
+			}()
+
+			log.Printf("ccheck serving on %s (namespace=%s, policies=%s)", addr, namespace, policyDir)
+			return http.ListenAndServe(addr, s.Handler())
+		},
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/brendanjryan/ccheck/pkg"
+	"github.com/brendanjryan/ccheck/pkg/reporter"
+	"github.com/open-policy-agent/opa/cover"
+	"github.com/open-policy-agent/opa/storage/inmem"
+	"github.com/open-policy-agent/opa/tester"
+	"github.com/urfave/cli"
+)
+
+// testCommand builds the `ccheck test` subcommand, which discovers
+// *_test.rego files alongside a directory's policies and runs every
+// test_* rule against them -- the same unit-testing workflow `opa test`
+// offers, wired into ccheck so policy authors don't need a second binary.
+func testCommand() cli.Command {
+	var coverage bool
+	var minCoverage float64
+	var format string
+
+	return cli.Command{
+		Name:      "test",
+		Usage:     "test <policyDir> -- run *_test.rego policy tests",
+		ArgsUsage: "<policyDir>",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:        "coverage",
+				Usage:       "report per-file line coverage",
+				Destination: &coverage,
+			},
+			cli.Float64Flag{
+				Name:        "min-coverage",
+				Usage:       "fail the run if line coverage drops below this percentage (implies --coverage)",
+				Destination: &minCoverage,
+			},
+			cli.StringFlag{
+				Name:        "f, format",
+				Usage:       "output format: text, json, sarif, junit, github",
+				Destination: &format,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			policyDir := c.Args().First()
+			if policyDir == "" {
+				policyDir = "policies"
+			}
+
+			return runPolicyTests(context.Background(), policyDir, coverage || minCoverage > 0, minCoverage, format)
+		},
+	}
+}
+
+// runPolicyTests compiles the policies (and any *_test.rego files alongside
+// them) in policyDir, runs every discovered test, and reports each test's
+// result through the same Reporter pipeline the default `ccheck <files>` run
+// uses, so --format is honored here too, followed by a plain-text pass/fail
+// count on stdout. When trackCoverage is set it also reports
+// per-file line coverage, failing the run if it's below minCoverage.
+func runPolicyTests(ctx context.Context, policyDir string, trackCoverage bool, minCoverage float64, format string) error {
+	compiler := pkg.NewCompiler([]string{policyDir}, pkg.WithNoEmbeddedBundle(true))
+	if err := compiler.Build(ctx); err != nil {
+		return cli.NewExitError("error compiling policies: "+err.Error(), 1)
+	}
+
+	runner := tester.NewRunner().SetCompiler(compiler.Compiler).SetStore(inmem.New())
+
+	var covTracer *cover.Cover
+	if trackCoverage {
+		covTracer = cover.New()
+		runner = runner.SetCoverageTracer(covTracer)
+	}
+
+	ch, err := runner.Run(ctx, compiler.Modules)
+	if err != nil {
+		return cli.NewExitError("error running tests: "+err.Error(), 1)
+	}
+
+	r, err := reporter.New(format, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	passed, failed := 0, 0
+	for res := range ch {
+		name := fmt.Sprintf("%s.%s", res.Package, res.Name)
+
+		switch {
+		case res.Fail:
+			failed++
+			msg := fmt.Sprintf("failed (%s)", res.Duration)
+			if res.Error != nil {
+				msg = fmt.Sprintf("%s: %s", msg, res.Error)
+			}
+			r.Err(name, pkg.Finding{Message: msg})
+		case res.Skip:
+			r.Warning(name, pkg.Finding{Message: "skipped"})
+		default:
+			passed++
+			r.Ok(name)
+		}
+	}
+
+	if err := r.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d passed, %d failed\n", passed, failed)
+
+	if covTracer != nil {
+		report := covTracer.Report(compiler.Modules)
+		for path, fr := range report.Files {
+			fmt.Printf("%s: %.1f%% coverage\n", path, fr.Coverage)
+		}
+
+		if minCoverage > 0 && report.Coverage < minCoverage {
+			return cli.NewExitError(fmt.Sprintf("coverage %.1f%% is below minimum %.1f%%", report.Coverage, minCoverage), 1)
+		}
+	}
+
+	if failed > 0 {
+		return cli.NewExitError("one or more policy tests failed", 1)
+	}
+
+	return nil
+}